@@ -0,0 +1,223 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// bulkItemStatus is the outcome of processing a single receipt within a bulk job.
+type bulkItemStatus struct {
+	ID      string `json:"id,omitempty"`
+	Points  int    `json:"points,omitempty"`
+	Error   string `json:"error,omitempty"`
+	Comment string `json:"comment,omitempty"`
+}
+
+// bulkJob tracks the progress of a single POST /receipts/bulk request.
+type bulkJob struct {
+	io     sync.RWMutex
+	Status string           `json:"status"` // "processing" or "done"
+	Items  []bulkItemStatus `json:"items"`
+}
+
+// bulkJobStore holds in-flight and completed bulk jobs. Jobs are transient,
+// so these live outside ReceiptStore rather than the persistent backend.
+var bulkJobStore = struct {
+	io   sync.RWMutex
+	jobs map[string]*bulkJob
+}{jobs: make(map[string]*bulkJob)}
+
+// bulkWorkerCount bounds how many receipts a bulk job processes concurrently.
+// It defaults to 8 but is overridden by main via the --bulk-workers flag (or
+// RECEIPT_BULK_WORKERS env var), mirroring MaxBodyBytes.
+var bulkWorkerCount = 8
+
+// bulkJobTimeout bounds how long a single bulk job is allowed to run before
+// its remaining items are canceled, so a slow store can't hang a job forever.
+const bulkJobTimeout = 5 * time.Minute
+
+// bulkShutdownCtx is canceled by cancelBulkJobs during graceful shutdown, so
+// every in-flight bulk job's ctx (derived from it via deadlineTimer) is
+// canceled too, rather than being abandoned when the process exits.
+var bulkShutdownCtx, cancelBulkJobs = context.WithCancel(context.Background())
+
+// bulkJobsWG tracks running bulk jobs so shutdown can wait for them to
+// observe cancellation and stop touching the store before it's closed.
+var bulkJobsWG sync.WaitGroup
+
+// waitForBulkJobs blocks until every in-flight bulk job has returned, or
+// until ctx is done, whichever comes first.
+func waitForBulkJobs(ctx context.Context) {
+	done := make(chan struct{})
+	go func() {
+		bulkJobsWG.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		log.Printf("bulk: timed out waiting for in-flight jobs to drain")
+	}
+}
+
+// processBulk accepts an array of receipts, kicks off worker-pool processing
+// in the background, and immediately returns a job ID for polling.
+func processBulk(writer http.ResponseWriter, request *http.Request) {
+	var receipts []Receipt
+	if !LoadDataFromRequest(writer, request, &receipts) {
+		return
+	}
+
+	job := &bulkJob{
+		Status: "processing",
+		Items:  make([]bulkItemStatus, len(receipts)),
+	}
+	jobID := uuid.New().String()
+
+	bulkJobStore.io.Lock()
+	bulkJobStore.jobs[jobID] = job
+	bulkJobStore.io.Unlock()
+
+	// The job outlives this request, so it gets its own bounded deadline
+	// rather than inheriting the (already-returned) request context. It's
+	// derived from bulkShutdownCtx so graceful shutdown can cancel it too.
+	ctx, cancel := deadlineTimer(bulkShutdownCtx, bulkJobTimeout)
+	bulkJobsWG.Add(1)
+	go func() {
+		defer bulkJobsWG.Done()
+		defer cancel()
+		runBulkJob(ctx, job, receipts)
+	}()
+
+	writer.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(writer).Encode(ID{ID: jobID})
+}
+
+// runBulkJob processes each receipt in a bounded worker pool, recording a
+// per-item result so one bad receipt never fails the whole batch. Every
+// worker shares ctx, so once it's canceled (job timeout, or graceful
+// shutdown via cancelBulkJobs) the pool stops picking up new work; any
+// receipt that never got dispatched is recorded with ctx's error rather
+// than left as a zero-value (indistinguishable-from-unset) result.
+func runBulkJob(ctx context.Context, job *bulkJob, receipts []Receipt) {
+	work := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < bulkWorkerCount; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range work {
+				result := processBulkItem(ctx, receipts[i])
+				job.io.Lock()
+				job.Items[i] = result
+				job.io.Unlock()
+			}
+		}()
+	}
+
+	dispatched := 0
+feed:
+	for i := range receipts {
+		select {
+		case work <- i:
+			dispatched++
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(work)
+	wg.Wait()
+
+	if dispatched < len(receipts) {
+		job.io.Lock()
+		for i := dispatched; i < len(receipts); i++ {
+			job.Items[i] = bulkItemStatus{Error: ctx.Err().Error()}
+		}
+		job.io.Unlock()
+	}
+
+	job.io.Lock()
+	job.Status = "done"
+	job.io.Unlock()
+}
+
+func processBulkItem(ctx context.Context, receipt Receipt) bulkItemStatus {
+	if err := ctx.Err(); err != nil {
+		return bulkItemStatus{Error: err.Error()}
+	}
+
+	if errs := validateReceipt(receipt); len(errs) > 0 {
+		return bulkItemStatus{Error: joinValidationErrors(errs)}
+	}
+
+	score := engine.Calculate(receipt)
+	id := uuid.New().String()
+
+	if err := store.Save(ctx, id, receipt, score); err != nil {
+		return bulkItemStatus{Error: err.Error()}
+	}
+
+	return bulkItemStatus{ID: id, Points: score.Points}
+}
+
+// getBulkStatus returns the current per-item status of a bulk job.
+func getBulkStatus(writer http.ResponseWriter, request *http.Request) {
+	jobID := mux.Vars(request)["jobId"]
+
+	bulkJobStore.io.RLock()
+	job, exists := bulkJobStore.jobs[jobID]
+	bulkJobStore.io.RUnlock()
+
+	if !exists {
+		http.Error(writer, "Job not found", http.StatusNotFound)
+		return
+	}
+
+	job.io.RLock()
+	defer job.io.RUnlock()
+
+	writer.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(writer).Encode(job)
+}
+
+// bulkStatusUpdate is one entry in a POST /receipts/bulk/status request,
+// updating the status/comment recorded against a previously processed receipt.
+type bulkStatusUpdate struct {
+	ID      string `json:"id"`
+	Status  string `json:"status"`
+	Comment string `json:"comment,omitempty"`
+}
+
+// updateBulkStatus applies status/comment updates to many receipts at once,
+// reporting per-item errors rather than failing the whole request.
+func updateBulkStatus(writer http.ResponseWriter, request *http.Request) {
+	var updates []bulkStatusUpdate
+	if !LoadDataFromRequest(writer, request, &updates) {
+		return
+	}
+
+	ctx := request.Context()
+	results := make([]bulkItemStatus, len(updates))
+	for i, update := range updates {
+		if exists, err := store.UpdateStatus(ctx, update.ID, update.Status, update.Comment); err != nil {
+			results[i] = bulkItemStatus{ID: update.ID, Error: err.Error()}
+		} else if !exists {
+			results[i] = bulkItemStatus{ID: update.ID, Error: "receipt not found"}
+		} else {
+			results[i] = bulkItemStatus{ID: update.ID, Comment: update.Comment}
+		}
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(writer).Encode(results)
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// LoadDataFromRequest decodes a JSON request body into dest, applying the
+// same body-size limit and unknown-field rejection as the receipt
+// endpoints. On failure it writes a structured validation error and
+// returns false; callers should return immediately in that case. This is
+// the shared decode step new command-style endpoints (e.g. search) build on.
+func LoadDataFromRequest(writer http.ResponseWriter, request *http.Request, dest any) bool {
+	request.Body = http.MaxBytesReader(writer, request.Body, MaxBodyBytes)
+
+	decoder := json.NewDecoder(request.Body)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(dest); err != nil {
+		writeValidationErrors(writer, []ValidationError{{
+			Code:    "invalid_body",
+			Message: err.Error(),
+		}})
+		return false
+	}
+
+	return true
+}
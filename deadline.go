@@ -0,0 +1,18 @@
+package main
+
+import (
+	"context"
+	"time"
+)
+
+// deadlineTimer derives a context bounded by maxDuration, or by parent's own
+// deadline if it already has one that's sooner. It's the shared building
+// block for coordinating cancellation across the bulk endpoint's worker
+// pool: every worker and every store call shares the same deadline, so a
+// slow backend can't let one bulk job run forever.
+func deadlineTimer(parent context.Context, maxDuration time.Duration) (context.Context, context.CancelFunc) {
+	if deadline, ok := parent.Deadline(); ok && time.Until(deadline) < maxDuration {
+		return context.WithDeadline(parent, deadline)
+	}
+	return context.WithTimeout(parent, maxDuration)
+}
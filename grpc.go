@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/gunnarhurst/ReceiptProcessor/proto"
+)
+
+// grpcServer implements the generated ReceiptProcessor service on top of the
+// same store and rule engine the HTTP handlers use.
+type grpcServer struct {
+	pb.UnimplementedReceiptProcessorServer
+}
+
+func (s *grpcServer) ProcessReceipt(ctx context.Context, req *pb.ProcessReceiptRequest) (*pb.ProcessReceiptResponse, error) {
+	receipt := receiptFromProto(req.GetReceipt())
+
+	if errs := validateReceipt(receipt); len(errs) > 0 {
+		return nil, grpcValidationError(errs)
+	}
+
+	score := engine.Calculate(receipt)
+	id := uuid.New().String()
+
+	if err := store.Save(ctx, id, receipt, score); err != nil {
+		return nil, err
+	}
+
+	return &pb.ProcessReceiptResponse{Id: id}, nil
+}
+
+func (s *grpcServer) GetPoints(ctx context.Context, req *pb.GetPointsRequest) (*pb.GetPointsResponse, error) {
+	points, exists, err := store.GetPoints(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, status.Errorf(codes.NotFound, "receipt %q not found", req.GetId())
+	}
+
+	return &pb.GetPointsResponse{Points: int32(points)}, nil
+}
+
+func receiptFromProto(r *pb.Receipt) Receipt {
+	items := make([]Item, len(r.GetItems()))
+	for i, item := range r.GetItems() {
+		items[i] = Item{ShortDescr: item.GetShortDescription(), Price: item.GetPrice()}
+	}
+
+	return Receipt{
+		Retailer:     r.GetRetailer(),
+		PurchaseDate: r.GetPurchaseDate(),
+		PurchaseTime: r.GetPurchaseTime(),
+		Items:        items,
+		Total:        r.GetTotal(),
+	}
+}
+
+// grpcValidationError reports every violation (matching the HTTP path's
+// writeValidationErrors) as a single codes.InvalidArgument status, so the
+// grpc-gateway proxy maps it to HTTP 400 instead of falling back to 500.
+func grpcValidationError(errs []ValidationError) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return status.Error(codes.InvalidArgument, "validation failed: "+joinValidationErrors(errs))
+}
+
+// runGRPCServer serves the gRPC API (with reflection and a health service)
+// on grpcAddr, and a grpc-gateway REST proxy exposing the same JSON surface
+// as the existing HTTP handlers on gatewayAddr. The returned stop func
+// drains both of them; callers must invoke it before closing the shared
+// store so no handler is left writing to an already-closed backend.
+func runGRPCServer(grpcAddr, gatewayAddr string) (stop func(ctx context.Context), err error) {
+	listener, err := net.Listen("tcp", grpcAddr)
+	if err != nil {
+		return nil, fmt.Errorf("grpc: listen on %s: %w", grpcAddr, err)
+	}
+
+	server := grpc.NewServer()
+	pb.RegisterReceiptProcessorServer(server, &grpcServer{})
+	reflection.Register(server)
+
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(server, healthServer)
+
+	go func() {
+		log.Printf("gRPC server starting on %s...", grpcAddr)
+		if err := server.Serve(listener); err != nil {
+			log.Printf("grpc: serve: %v", err)
+		}
+	}()
+
+	mux := runtime.NewServeMux()
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	if err := pb.RegisterReceiptProcessorHandlerFromEndpoint(context.Background(), mux, grpcAddr, opts); err != nil {
+		return nil, fmt.Errorf("grpc-gateway: register: %w", err)
+	}
+
+	gateway := &http.Server{Addr: gatewayAddr, Handler: mux}
+	go func() {
+		log.Printf("grpc-gateway REST proxy starting on %s...", gatewayAddr)
+		if err := gateway.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Printf("grpc-gateway: serve: %v", err)
+		}
+	}()
+
+	return func(ctx context.Context) {
+		// server.GracefulStop waits for every in-flight RPC to finish with no
+		// timeout of its own, so it's raced against ctx here the same way
+		// gateway.Shutdown already bounds the REST proxy; a client that never
+		// finishes gets its connection cut by server.Stop instead of hanging
+		// shutdown forever.
+		stopped := make(chan struct{})
+		go func() {
+			server.GracefulStop()
+			close(stopped)
+		}()
+
+		select {
+		case <-stopped:
+		case <-ctx.Done():
+			log.Printf("grpc: graceful stop timed out, forcing shutdown")
+			server.Stop()
+		}
+
+		if err := gateway.Shutdown(ctx); err != nil {
+			log.Printf("grpc-gateway: shutdown: %v", err)
+		}
+	}, nil
+}
@@ -1,14 +1,16 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
+	"flag"
+	"fmt"
 	"log"
-	"math"
 	"net/http"
-	"regexp"
+	"os"
+	"os/signal"
 	"strconv"
-	"strings"
-	"sync"
+	"syscall"
 	"time"
 
 	"github.com/google/uuid"
@@ -37,54 +39,217 @@ type Points struct {
 	Points int `json:"points"`
 }
 
-type Storage struct {
-	receipts map[string]int
-	io       sync.RWMutex
-}
+var store ReceiptStore
+var engine *RuleEngine
 
-var store = &Storage{
-	receipts: make(map[string]int),
-}
+// shutdownGracePeriod bounds how long graceful shutdown waits for in-flight
+// requests to drain before giving up.
+const shutdownGracePeriod = 10 * time.Second
 
 func main() {
+	storageURL := flag.String("storage", "", "storage backend: memory, bolt://path, sqlite://path, or a postgres:// URL (defaults to RECEIPT_STORAGE_URL, then memory)")
+	rulesPath := flag.String("rules", "", "path to a YAML/JSON rule engine config (defaults to the built-in rule set)")
+	grpcAddr := flag.String("grpc-addr", ":9090", "address the gRPC server listens on")
+	gatewayAddr := flag.String("grpc-gateway-addr", ":9091", "address the grpc-gateway REST proxy listens on")
+	readTimeout := flag.Duration("read-timeout", 5*time.Second, "HTTP server ReadTimeout")
+	writeTimeout := flag.Duration("write-timeout", 10*time.Second, "HTTP server WriteTimeout")
+	idleTimeout := flag.Duration("idle-timeout", 120*time.Second, "HTTP server IdleTimeout")
+	readHeaderTimeout := flag.Duration("read-header-timeout", 5*time.Second, "HTTP server ReadHeaderTimeout")
+	maxBodyBytes := flag.Int64("max-body-bytes", 0, "maximum accepted request body size, in bytes (defaults to RECEIPT_MAX_BODY_BYTES, then 1 MiB)")
+	bulkWorkers := flag.Int("bulk-workers", 0, "number of receipts a bulk job processes concurrently (defaults to RECEIPT_BULK_WORKERS, then 8)")
+	flag.Parse()
+
+	if resolved, err := resolveMaxBodyBytes(*maxBodyBytes); err != nil {
+		log.Fatalf("max-body-bytes: %v", err)
+	} else if resolved > 0 {
+		MaxBodyBytes = resolved
+	}
+
+	if resolved, err := resolveBulkWorkers(*bulkWorkers); err != nil {
+		log.Fatalf("bulk-workers: %v", err)
+	} else if resolved > 0 {
+		bulkWorkerCount = resolved
+	}
+
+	var err error
+	store, err = newStore(*storageURL)
+	if err != nil {
+		log.Fatalf("storage: %v", err)
+	}
+
+	if *rulesPath != "" {
+		config, err := LoadRuleEngineConfig(*rulesPath)
+		if err != nil {
+			log.Fatalf("rules: %v", err)
+		}
+		engine, err = NewRuleEngine(config)
+		if err != nil {
+			log.Fatalf("rules: %v", err)
+		}
+	} else {
+		engine = NewDefaultRuleEngine()
+	}
+
+	stopGRPC, err := runGRPCServer(*grpcAddr, *gatewayAddr)
+	if err != nil {
+		log.Fatalf("grpc: %v", err)
+	}
+
 	router := mux.NewRouter()
 
 	router.HandleFunc("/receipts/process", processReceipt).Methods("POST")
 	router.HandleFunc("/receipts/{id}/points", getPoints).Methods("GET")
+	router.HandleFunc("/receipts/bulk", processBulk).Methods("POST")
+	router.HandleFunc("/receipts/bulk/status", updateBulkStatus).Methods("POST")
+	router.HandleFunc("/receipts/bulk/{jobId}", getBulkStatus).Methods("GET")
+	router.HandleFunc("/receipts/search", searchReceipts).Methods("POST")
+
+	server := &http.Server{
+		Addr:              ":8080",
+		Handler:           router,
+		ReadTimeout:       *readTimeout,
+		WriteTimeout:      *writeTimeout,
+		IdleTimeout:       *idleTimeout,
+		ReadHeaderTimeout: *readHeaderTimeout,
+	}
+
+	go func() {
+		log.Printf("Server starting on port 8080...")
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatalf("http: serve: %v", err)
+		}
+	}()
+
+	waitForShutdownSignal()
+
+	log.Printf("Shutting down...")
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownGracePeriod)
+	defer cancel()
+
+	// Stop accepting new work on every transport, cancel outstanding bulk
+	// jobs, then wait for all of it to drain before touching the store, so
+	// nothing is left writing to an already-closed backend.
+	if err := server.Shutdown(ctx); err != nil {
+		log.Printf("http: shutdown: %v", err)
+	}
+	stopGRPC(ctx)
+	cancelBulkJobs()
+	waitForBulkJobs(ctx)
+
+	if err := store.Close(); err != nil {
+		log.Printf("storage: close: %v", err)
+	}
+}
+
+// resolveMaxBodyBytes picks the effective MaxBodyBytes: the --max-body-bytes
+// flag if set, else RECEIPT_MAX_BODY_BYTES, else the package default
+// (signaled by returning 0, which the caller leaves untouched).
+func resolveMaxBodyBytes(flagValue int64) (int64, error) {
+	if flagValue > 0 {
+		return flagValue, nil
+	}
+
+	envValue := os.Getenv("RECEIPT_MAX_BODY_BYTES")
+	if envValue == "" {
+		return 0, nil
+	}
+
+	parsed, err := strconv.ParseInt(envValue, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("RECEIPT_MAX_BODY_BYTES: %w", err)
+	}
+	return parsed, nil
+}
+
+// resolveBulkWorkers picks the effective bulkWorkerCount: the --bulk-workers
+// flag if set, else RECEIPT_BULK_WORKERS, else the package default (signaled
+// by returning 0, which the caller leaves untouched).
+func resolveBulkWorkers(flagValue int) (int, error) {
+	if flagValue > 0 {
+		return flagValue, nil
+	}
+
+	envValue := os.Getenv("RECEIPT_BULK_WORKERS")
+	if envValue == "" {
+		return 0, nil
+	}
 
-	log.Printf("Server starting on port 8080...")
-	log.Fatal(http.ListenAndServe(":8080", router))
+	parsed, err := strconv.Atoi(envValue)
+	if err != nil {
+		return 0, fmt.Errorf("RECEIPT_BULK_WORKERS: %w", err)
+	}
+	return parsed, nil
+}
+
+// waitForShutdownSignal blocks until the process receives SIGINT or SIGTERM.
+func waitForShutdownSignal() {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	<-signals
 }
 
 // process each receipt and calculate points
 func processReceipt(writer http.ResponseWriter, router *http.Request) {
+	router.Body = http.MaxBytesReader(writer, router.Body, MaxBodyBytes)
+
+	decoder := json.NewDecoder(router.Body)
+	decoder.DisallowUnknownFields()
+
 	var receipt Receipt
-	if err := json.NewDecoder(router.Body).Decode(&receipt); err != nil {
-		http.Error(writer, "Invalid receipt format", http.StatusBadRequest)
+	if err := decoder.Decode(&receipt); err != nil {
+		writeValidationErrors(writer, []ValidationError{{
+			Code:    "invalid_body",
+			Field:   "",
+			Message: err.Error(),
+		}})
+		return
+	}
+
+	if errs := validateReceipt(receipt); len(errs) > 0 {
+		writeValidationErrors(writer, errs)
 		return
 	}
 
-	points := calculatePoints(receipt)
+	score := engine.Calculate(receipt)
 	id := uuid.New().String()
 
-	store.io.Lock()
-	store.receipts[id] = points
-	store.io.Unlock()
+	if err := store.Save(router.Context(), id, receipt, score); err != nil {
+		http.Error(writer, "Failed to save receipt", http.StatusInternalServerError)
+		return
+	}
 
 	response := ID{ID: id}
 	writer.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(writer).Encode(response)
 }
 
-// get points for each receipt
+// get points for each receipt. Pass ?explain=true for the per-rule breakdown.
 func getPoints(writer http.ResponseWriter, router *http.Request) {
 	vars := mux.Vars(router)
 	id := vars["id"]
+	ctx := router.Context()
 
-	store.io.RLock()
-	points, exists := store.receipts[id]
-	store.io.RUnlock()
+	if router.URL.Query().Get("explain") == "true" {
+		record, exists, err := store.Get(ctx, id)
+		if err != nil {
+			http.Error(writer, "Failed to look up receipt", http.StatusInternalServerError)
+			return
+		}
+		if !exists {
+			http.Error(writer, "Receipt not found", http.StatusNotFound)
+			return
+		}
 
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode(record.Score)
+		return
+	}
+
+	points, exists, err := store.GetPoints(ctx, id)
+	if err != nil {
+		http.Error(writer, "Failed to look up receipt", http.StatusInternalServerError)
+		return
+	}
 	if !exists {
 		http.Error(writer, "Receipt not found", http.StatusNotFound)
 		return
@@ -94,53 +259,3 @@ func getPoints(writer http.ResponseWriter, router *http.Request) {
 	writer.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(writer).Encode(response)
 }
-
-// calculate point values according to spec. Returns point amt.
-func calculatePoints(receipt Receipt) int {
-	points := 0
-
-	// 1: One point for every alphanumeric character in the retailer name
-	alphanumeric := regexp.MustCompile(`[a-zA-Z0-9]`)
-	points += len(alphanumeric.FindAllString(receipt.Retailer, -1))
-
-	// 2: 50 points if the total is a round dollar amount
-	total, _ := strconv.ParseFloat(receipt.Total, 64)
-	if total == math.Floor(total) {
-		points += 50
-	}
-
-	// 3: 25 points if the total is a multiple of 0.25
-	if math.Mod(total*100, 25) == 0 {
-		points += 25
-	}
-
-	// 4: 5 points for every two items
-	points += (len(receipt.Items) / 2) * 5
-
-	// 5: Points for items whose description length is a multiple of 3
-	for _, item := range receipt.Items {
-
-		trimmedLen := len(strings.TrimSpace(item.ShortDescr))
-		if trimmedLen%3 == 0 {
-			price, _ := strconv.ParseFloat(item.Price, 64)
-			points += int(math.Ceil(price * 0.2))
-		}
-	}
-
-	// 6: 6 points if the day in the purchase date is odd
-	purchaseDate, _ := time.Parse("2006-01-02", receipt.PurchaseDate)
-	if purchaseDate.Day()%2 == 1 {
-		points += 6
-	}
-
-	// 7: 10 points if time is between 2:00pm and 4:00pm
-	purchaseTime, _ := time.Parse("15:04", receipt.PurchaseTime)
-	targetStart, _ := time.Parse("15:04", "14:00")
-	targetEnd, _ := time.Parse("15:04", "16:00")
-
-	if purchaseTime.After(targetStart) && purchaseTime.Before(targetEnd) {
-		points += 10
-	}
-
-	return points
-}
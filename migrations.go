@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"sort"
+)
+
+//go:embed migrations/sqlite/*.sql
+var sqliteMigrations embed.FS
+
+//go:embed migrations/postgres/*.sql
+var postgresMigrations embed.FS
+
+// runMigrations applies every *.sql file under dir (one of the embedded
+// migrations filesystems above) that hasn't already run, in filename order,
+// tracking progress in a schema_migrations table. This replaces a single
+// hand-maintained "CREATE TABLE IF NOT EXISTS" schema string, so a binary
+// upgrade against an existing database picks up the new ALTER TABLE
+// statements instead of silently no-op'ing against them.
+func runMigrations(ctx context.Context, db *sql.DB, migrations embed.FS, dir string, placeholder func(int) string) error {
+	if _, err := db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (name TEXT PRIMARY KEY)`); err != nil {
+		return fmt.Errorf("migrations: create tracking table: %w", err)
+	}
+
+	entries, err := fs.ReadDir(migrations, dir)
+	if err != nil {
+		return fmt.Errorf("migrations: read %q: %w", dir, err)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+
+	for _, entry := range entries {
+		name := entry.Name()
+
+		var applied int
+		query := fmt.Sprintf(`SELECT count(*) FROM schema_migrations WHERE name = %s`, placeholder(1))
+		if err := db.QueryRowContext(ctx, query, name).Scan(&applied); err != nil {
+			return fmt.Errorf("migrations: check %q: %w", name, err)
+		}
+		if applied > 0 {
+			continue
+		}
+
+		data, err := migrations.ReadFile(dir + "/" + name)
+		if err != nil {
+			return fmt.Errorf("migrations: read %q: %w", name, err)
+		}
+		if _, err := db.ExecContext(ctx, string(data)); err != nil {
+			return fmt.Errorf("migrations: apply %q: %w", name, err)
+		}
+
+		insert := fmt.Sprintf(`INSERT INTO schema_migrations (name) VALUES (%s)`, placeholder(1))
+		if _, err := db.ExecContext(ctx, insert, name); err != nil {
+			return fmt.Errorf("migrations: record %q: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// questionPlaceholder is the ?-style positional placeholder SQLite uses.
+func questionPlaceholder(int) string { return "?" }
+
+// dollarPlaceholder is the $N-style positional placeholder Postgres uses.
+func dollarPlaceholder(n int) string { return fmt.Sprintf("$%d", n) }
@@ -0,0 +1,199 @@
+package main
+
+import "testing"
+
+func receiptFixture() Receipt {
+	return Receipt{
+		Retailer:     "Target",
+		PurchaseDate: "2022-01-01",
+		PurchaseTime: "13:01",
+		Total:        "35.00",
+		Items: []Item{
+			{ShortDescr: "Mountain Dew 12PK", Price: "6.49"},
+			{ShortDescr: "Emils Cheese Pizza", Price: "12.25"},
+			{ShortDescr: "Knorr Creamy Chicken", Price: "1.26"},
+			{ShortDescr: "Doritos Nacho Cheese", Price: "3.35"},
+		},
+	}
+}
+
+func TestRoundDollarTotalRule(t *testing.T) {
+	tests := []struct {
+		name   string
+		total  string
+		points int
+	}{
+		{"round dollar", "35.00", 50},
+		{"not round", "35.35", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			receipt := Receipt{Total: tt.total}
+			points, _ := roundDollarTotalRule{}.Points(receipt)
+			if points != tt.points {
+				t.Errorf("Points() = %d, want %d", points, tt.points)
+			}
+		})
+	}
+}
+
+func TestQuarterMultipleTotalRule(t *testing.T) {
+	tests := []struct {
+		name   string
+		total  string
+		points int
+	}{
+		{"multiple of quarter", "35.25", 25},
+		{"round dollar is also a multiple", "35.00", 25},
+		{"not a multiple", "35.35", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			receipt := Receipt{Total: tt.total}
+			points, _ := quarterMultipleTotalRule{}.Points(receipt)
+			if points != tt.points {
+				t.Errorf("Points() = %d, want %d", points, tt.points)
+			}
+		})
+	}
+}
+
+func TestItemPairsRule(t *testing.T) {
+	tests := []struct {
+		name   string
+		items  int
+		points int
+	}{
+		{"no items", 0, 0},
+		{"one item", 1, 0},
+		{"two items", 2, 5},
+		{"three items", 3, 5},
+		{"four items", 4, 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			receipt := Receipt{Items: make([]Item, tt.items)}
+			points, _ := itemPairsRule{}.Points(receipt)
+			if points != tt.points {
+				t.Errorf("Points() = %d, want %d", points, tt.points)
+			}
+		})
+	}
+}
+
+func TestItemDescriptionRule(t *testing.T) {
+	receipt := Receipt{
+		Items: []Item{
+			{ShortDescr: "Emils Cheese Pizza", Price: "12.25"}, // len 18, multiple of 3
+			{ShortDescr: "Gatorade", Price: "2.25"},            // len 8, not a multiple of 3
+		},
+	}
+
+	rule := itemDescriptionRule{priceMultiplier: 0.2}
+	points, _ := rule.Points(receipt)
+
+	want := 3 // ceil(12.25 * 0.2) = 3
+	if points != want {
+		t.Errorf("Points() = %d, want %d", points, want)
+	}
+}
+
+func TestOddPurchaseDayRule(t *testing.T) {
+	tests := []struct {
+		name   string
+		date   string
+		points int
+	}{
+		{"odd day", "2022-01-01", 6},
+		{"even day", "2022-01-02", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			receipt := Receipt{PurchaseDate: tt.date}
+			points, _ := oddPurchaseDayRule{}.Points(receipt)
+			if points != tt.points {
+				t.Errorf("Points() = %d, want %d", points, tt.points)
+			}
+		})
+	}
+}
+
+func TestAfternoonPurchaseWindowRule(t *testing.T) {
+	rule := afternoonPurchaseWindowRule{windowStart: "14:00", windowEnd: "16:00"}
+
+	tests := []struct {
+		name   string
+		time   string
+		points int
+	}{
+		{"inside window", "14:33", 10},
+		{"before window", "13:59", 0},
+		{"after window", "16:01", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			receipt := Receipt{PurchaseTime: tt.time}
+			points, _ := rule.Points(receipt)
+			if points != tt.points {
+				t.Errorf("Points() = %d, want %d", points, tt.points)
+			}
+		})
+	}
+}
+
+func TestRuleEngineCalculate(t *testing.T) {
+	engine := NewDefaultRuleEngine()
+	result := engine.Calculate(receiptFixture())
+
+	if result.Version != defaultRuleVersion {
+		t.Errorf("Version = %q, want %q", result.Version, defaultRuleVersion)
+	}
+	if len(result.Breakdown) != len(engine.Rules) {
+		t.Errorf("len(Breakdown) = %d, want %d", len(result.Breakdown), len(engine.Rules))
+	}
+
+	var summed int
+	for _, line := range result.Breakdown {
+		summed += line.Points
+	}
+	if summed != result.Points {
+		t.Errorf("Points = %d, want sum of breakdown %d", result.Points, summed)
+	}
+}
+
+func TestNewRuleEngineUnknownRule(t *testing.T) {
+	config := RuleEngineConfig{
+		Version: "bad",
+		Rules:   []RuleConfig{{Name: "not_a_real_rule", Enabled: true}},
+	}
+
+	if _, err := NewRuleEngine(config); err == nil {
+		t.Error("NewRuleEngine() error = nil, want error for unknown rule name")
+	}
+}
+
+func TestNewRuleEngineSkipsDisabledRules(t *testing.T) {
+	config := RuleEngineConfig{
+		Version: "v2",
+		Rules: []RuleConfig{
+			{Name: "round_dollar_total", Enabled: true},
+			{Name: "quarter_multiple_total", Enabled: false},
+		},
+	}
+
+	engine, err := NewRuleEngine(config)
+	if err != nil {
+		t.Fatalf("NewRuleEngine() error = %v", err)
+	}
+	if len(engine.Rules) != 1 {
+		t.Fatalf("len(Rules) = %d, want 1", len(engine.Rules))
+	}
+	if engine.Rules[0].Name() != "round_dollar_total" {
+		t.Errorf("Rules[0].Name() = %q, want %q", engine.Rules[0].Name(), "round_dollar_total")
+	}
+}
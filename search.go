@@ -0,0 +1,104 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// defaultPageSize caps how many records a PagedRequestCommand returns when
+// PageSize is left unset.
+const defaultPageSize = 20
+
+// PagedRequestCommand is the body of POST /receipts/search: a filter plus
+// paging and sort controls.
+type PagedRequestCommand struct {
+	Page          int        `json:"page"`
+	PageSize      int        `json:"pageSize"`
+	OrderBy       string     `json:"orderBy"`
+	SortDirection string     `json:"sortDirection"`
+	Filter        ListFilter `json:"filter"`
+}
+
+// PagedResult is the response of POST /receipts/search.
+type PagedResult struct {
+	Data       []Record `json:"data"`
+	TotalCount int      `json:"totalCount"`
+	Page       int      `json:"page"`
+	PageSize   int      `json:"pageSize"`
+}
+
+// searchReceipts filters, sorts, and paginates receipts. Filtering is
+// pushed down to the store; sorting and paging happen here so they behave
+// identically regardless of backend.
+func searchReceipts(writer http.ResponseWriter, request *http.Request) {
+	command := PagedRequestCommand{Page: 1, PageSize: defaultPageSize}
+	if !LoadDataFromRequest(writer, request, &command) {
+		return
+	}
+
+	if command.Page < 1 {
+		command.Page = 1
+	}
+	if command.PageSize < 1 {
+		command.PageSize = defaultPageSize
+	}
+
+	records, err := store.List(request.Context(), command.Filter)
+	if err != nil {
+		http.Error(writer, "Failed to list receipts", http.StatusInternalServerError)
+		return
+	}
+
+	sortRecords(records, command.OrderBy, command.SortDirection)
+
+	start := (command.Page - 1) * command.PageSize
+	end := start + command.PageSize
+	if start > len(records) {
+		start = len(records)
+	}
+	if end > len(records) {
+		end = len(records)
+	}
+
+	response := PagedResult{
+		Data:       records[start:end],
+		TotalCount: len(records),
+		Page:       command.Page,
+		PageSize:   command.PageSize,
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(writer).Encode(response)
+}
+
+// sortRecords orders records in place by orderBy ("retailer", "total",
+// "points", or "purchaseDate"; defaults to "purchaseDate") and
+// sortDirection ("asc" or "desc"; defaults to "asc").
+func sortRecords(records []Record, orderBy, sortDirection string) {
+	descending := strings.EqualFold(sortDirection, "desc")
+
+	less := func(i, j int) bool {
+		switch orderBy {
+		case "retailer":
+			return records[i].Receipt.Retailer < records[j].Receipt.Retailer
+		case "total":
+			a, _ := strconv.ParseFloat(records[i].Receipt.Total, 64)
+			b, _ := strconv.ParseFloat(records[j].Receipt.Total, 64)
+			return a < b
+		case "points":
+			return records[i].Score.Points < records[j].Score.Points
+		default:
+			return records[i].Receipt.PurchaseDate < records[j].Receipt.PurchaseDate
+		}
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		if descending {
+			return less(j, i)
+		}
+		return less(i, j)
+	})
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func recordFixtures() []Record {
+	return []Record{
+		{ID: "a", Receipt: Receipt{Retailer: "Target", PurchaseDate: "2022-01-03", Total: "10.00"}, Score: ScoreResult{Points: 30}},
+		{ID: "b", Receipt: Receipt{Retailer: "Walmart", PurchaseDate: "2022-01-01", Total: "25.50"}, Score: ScoreResult{Points: 10}},
+		{ID: "c", Receipt: Receipt{Retailer: "Costco", PurchaseDate: "2022-01-02", Total: "5.25"}, Score: ScoreResult{Points: 20}},
+	}
+}
+
+func TestSortRecordsDefaultsToPurchaseDateAscending(t *testing.T) {
+	records := recordFixtures()
+	sortRecords(records, "", "")
+
+	want := []string{"b", "c", "a"}
+	for i, id := range want {
+		if records[i].ID != id {
+			t.Errorf("records[%d].ID = %q, want %q", i, records[i].ID, id)
+		}
+	}
+}
+
+func TestSortRecordsByField(t *testing.T) {
+	tests := []struct {
+		name          string
+		orderBy       string
+		sortDirection string
+		want          []string
+	}{
+		{"retailer asc", "retailer", "asc", []string{"c", "a", "b"}},
+		{"retailer desc", "retailer", "desc", []string{"b", "a", "c"}},
+		{"total asc", "total", "asc", []string{"c", "a", "b"}},
+		{"points desc", "points", "desc", []string{"a", "c", "b"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			records := recordFixtures()
+			sortRecords(records, tt.orderBy, tt.sortDirection)
+
+			for i, id := range tt.want {
+				if records[i].ID != id {
+					t.Errorf("records[%d].ID = %q, want %q", i, records[i].ID, id)
+				}
+			}
+		})
+	}
+}
+
+// postSearch drives the searchReceipts handler directly via httptest,
+// swapping in a MemoryStore seeded with fixtures for the duration of t.
+func postSearch(t *testing.T, command PagedRequestCommand) PagedResult {
+	t.Helper()
+
+	original := store
+	t.Cleanup(func() { store = original })
+
+	memStore := NewMemoryStore()
+	for _, record := range recordFixtures() {
+		memStore.records[record.ID] = record
+	}
+	store = memStore
+
+	body, err := json.Marshal(command)
+	if err != nil {
+		t.Fatalf("marshal command: %v", err)
+	}
+
+	request := httptest.NewRequest(http.MethodPost, "/receipts/search", bytes.NewReader(body))
+	recorder := httptest.NewRecorder()
+	searchReceipts(recorder, request)
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", recorder.Code, http.StatusOK)
+	}
+
+	var result PagedResult
+	if err := json.Unmarshal(recorder.Body.Bytes(), &result); err != nil {
+		t.Fatalf("unmarshal response: %v", err)
+	}
+	return result
+}
+
+func TestSearchReceiptsPagination(t *testing.T) {
+	result := postSearch(t, PagedRequestCommand{Page: 2, PageSize: 2, OrderBy: "purchaseDate"})
+
+	if result.TotalCount != 3 {
+		t.Errorf("TotalCount = %d, want 3", result.TotalCount)
+	}
+	if len(result.Data) != 1 {
+		t.Fatalf("len(Data) = %d, want 1", len(result.Data))
+	}
+	if result.Data[0].ID != "a" {
+		t.Errorf("Data[0].ID = %q, want %q", result.Data[0].ID, "a")
+	}
+}
+
+func TestSearchReceiptsPageBeyondLastIsEmpty(t *testing.T) {
+	result := postSearch(t, PagedRequestCommand{Page: 5, PageSize: 2})
+
+	if len(result.Data) != 0 {
+		t.Errorf("len(Data) = %d, want 0", len(result.Data))
+	}
+	if result.TotalCount != 3 {
+		t.Errorf("TotalCount = %d, want 3", result.TotalCount)
+	}
+}
+
+func TestSearchReceiptsDefaultsPageAndPageSize(t *testing.T) {
+	result := postSearch(t, PagedRequestCommand{})
+
+	if result.Page != 1 {
+		t.Errorf("Page = %d, want 1", result.Page)
+	}
+	if result.PageSize != defaultPageSize {
+		t.Errorf("PageSize = %d, want %d", result.PageSize, defaultPageSize)
+	}
+	if len(result.Data) != 3 {
+		t.Errorf("len(Data) = %d, want 3", len(result.Data))
+	}
+}
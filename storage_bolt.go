@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var receiptsBucket = []byte("receipts")
+
+// BoltStore persists receipts in a single-file BoltDB database, keyed by
+// receipt ID within the receipts bucket.
+type BoltStore struct {
+	db *bolt.DB
+}
+
+func NewBoltStore(path string) (*BoltStore, error) {
+	db, err := bolt.Open(path, 0600, nil)
+	if err != nil {
+		return nil, fmt.Errorf("bolt: open %q: %w", path, err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(receiptsBucket)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("bolt: create bucket: %w", err)
+	}
+
+	return &BoltStore{db: db}, nil
+}
+
+func (s *BoltStore) Save(ctx context.Context, id string, receipt Receipt, score ScoreResult) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	record := Record{ID: id, Receipt: receipt, Score: score}
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("bolt: marshal record: %w", err)
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(receiptsBucket).Put([]byte(id), data)
+	})
+}
+
+func (s *BoltStore) GetPoints(ctx context.Context, id string) (int, bool, error) {
+	record, exists, err := s.Get(ctx, id)
+	return record.Score.Points, exists, err
+}
+
+func (s *BoltStore) Get(ctx context.Context, id string) (Record, bool, error) {
+	if err := ctx.Err(); err != nil {
+		return Record{}, false, err
+	}
+
+	var record Record
+	var exists bool
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(receiptsBucket).Get([]byte(id))
+		if data == nil {
+			return nil
+		}
+		exists = true
+		return json.Unmarshal(data, &record)
+	})
+	if err != nil {
+		return Record{}, false, fmt.Errorf("bolt: unmarshal record: %w", err)
+	}
+
+	return record, exists, nil
+}
+
+func (s *BoltStore) List(ctx context.Context, filter ListFilter) ([]Record, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var records []Record
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(receiptsBucket).ForEach(func(key, data []byte) error {
+			var record Record
+			if err := json.Unmarshal(data, &record); err != nil {
+				return fmt.Errorf("bolt: unmarshal record %q: %w", key, err)
+			}
+			if filter.matches(record) {
+				records = append(records, record)
+			}
+			return nil
+		})
+	})
+
+	return records, err
+}
+
+func (s *BoltStore) UpdateStatus(ctx context.Context, id, status, comment string) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	var exists bool
+
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(receiptsBucket)
+		data := bucket.Get([]byte(id))
+		if data == nil {
+			return nil
+		}
+		exists = true
+
+		var record Record
+		if err := json.Unmarshal(data, &record); err != nil {
+			return fmt.Errorf("bolt: unmarshal record %q: %w", id, err)
+		}
+		record.Status = status
+		record.Comment = comment
+
+		updated, err := json.Marshal(record)
+		if err != nil {
+			return fmt.Errorf("bolt: marshal record %q: %w", id, err)
+		}
+		return bucket.Put([]byte(id), updated)
+	})
+
+	return exists, err
+}
+
+// Close flushes BoltDB's pending writes and closes the underlying file.
+func (s *BoltStore) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	_ "github.com/lib/pq"
+)
+
+// PostgresStore persists receipts in a Postgres database, identified by a
+// standard "postgres://" connection URL.
+type PostgresStore struct {
+	db *sql.DB
+}
+
+func NewPostgresStore(connURL string) (*PostgresStore, error) {
+	db, err := sql.Open("postgres", connURL)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: open: %w", err)
+	}
+
+	if err := runMigrations(context.Background(), db, postgresMigrations, "migrations/postgres", dollarPlaceholder); err != nil {
+		return nil, fmt.Errorf("postgres: %w", err)
+	}
+
+	return &PostgresStore{db: db}, nil
+}
+
+func (s *PostgresStore) Save(ctx context.Context, id string, receipt Receipt, score ScoreResult) error {
+	receiptData, err := json.Marshal(receipt)
+	if err != nil {
+		return fmt.Errorf("postgres: marshal receipt: %w", err)
+	}
+	breakdownData, err := json.Marshal(score.Breakdown)
+	if err != nil {
+		return fmt.Errorf("postgres: marshal breakdown: %w", err)
+	}
+
+	total, _ := strconv.ParseFloat(receipt.Total, 64)
+
+	_, err = s.db.ExecContext(ctx,
+		`INSERT INTO receipts (id, receipt, points, rule_version, breakdown, retailer, purchase_date, total)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		 ON CONFLICT (id) DO UPDATE SET receipt = excluded.receipt, points = excluded.points,
+		 rule_version = excluded.rule_version, breakdown = excluded.breakdown,
+		 retailer = excluded.retailer, purchase_date = excluded.purchase_date, total = excluded.total`,
+		id, receiptData, score.Points, score.Version, breakdownData, receipt.Retailer, receipt.PurchaseDate, total,
+	)
+	if err != nil {
+		return fmt.Errorf("postgres: save %q: %w", id, err)
+	}
+	return nil
+}
+
+func (s *PostgresStore) UpdateStatus(ctx context.Context, id, status, comment string) (bool, error) {
+	result, err := s.db.ExecContext(ctx,
+		`UPDATE receipts SET status = $1, comment = $2 WHERE id = $3`,
+		status, comment, id,
+	)
+	if err != nil {
+		return false, fmt.Errorf("postgres: update status %q: %w", id, err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("postgres: update status %q: %w", id, err)
+	}
+	return rows > 0, nil
+}
+
+func (s *PostgresStore) GetPoints(ctx context.Context, id string) (int, bool, error) {
+	var points int
+	err := s.db.QueryRowContext(ctx, `SELECT points FROM receipts WHERE id = $1`, id).Scan(&points)
+	if err == sql.ErrNoRows {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("postgres: get points %q: %w", id, err)
+	}
+	return points, true, nil
+}
+
+func (s *PostgresStore) Get(ctx context.Context, id string) (Record, bool, error) {
+	var record Record
+	var receiptData, breakdownData []byte
+
+	row := s.db.QueryRowContext(ctx, `SELECT id, receipt, points, rule_version, breakdown, status, comment FROM receipts WHERE id = $1`, id)
+	err := row.Scan(&record.ID, &receiptData, &record.Score.Points, &record.Score.Version, &breakdownData, &record.Status, &record.Comment)
+	if err == sql.ErrNoRows {
+		return Record{}, false, nil
+	}
+	if err != nil {
+		return Record{}, false, fmt.Errorf("postgres: get %q: %w", id, err)
+	}
+
+	if err := json.Unmarshal(receiptData, &record.Receipt); err != nil {
+		return Record{}, false, fmt.Errorf("postgres: unmarshal receipt %q: %w", id, err)
+	}
+	if err := json.Unmarshal(breakdownData, &record.Score.Breakdown); err != nil {
+		return Record{}, false, fmt.Errorf("postgres: unmarshal breakdown %q: %w", id, err)
+	}
+
+	return record, true, nil
+}
+
+func (s *PostgresStore) List(ctx context.Context, filter ListFilter) ([]Record, error) {
+	where, args := postgresFilterClause(filter)
+	query := `SELECT id, receipt, points, rule_version, breakdown, status, comment FROM receipts` + where
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: list: %w", err)
+	}
+	defer rows.Close()
+
+	var records []Record
+	for rows.Next() {
+		var record Record
+		var receiptData, breakdownData []byte
+		if err := rows.Scan(&record.ID, &receiptData, &record.Score.Points, &record.Score.Version, &breakdownData, &record.Status, &record.Comment); err != nil {
+			return nil, fmt.Errorf("postgres: scan row: %w", err)
+		}
+		if err := json.Unmarshal(receiptData, &record.Receipt); err != nil {
+			return nil, fmt.Errorf("postgres: unmarshal receipt %q: %w", record.ID, err)
+		}
+		if err := json.Unmarshal(breakdownData, &record.Score.Breakdown); err != nil {
+			return nil, fmt.Errorf("postgres: unmarshal breakdown %q: %w", record.ID, err)
+		}
+		records = append(records, record)
+	}
+	return records, rows.Err()
+}
+
+// postgresFilterClause translates a ListFilter into a "WHERE ..." clause
+// (or "" if the filter is empty) plus its positional ($N) arguments.
+func postgresFilterClause(filter ListFilter) (string, []any) {
+	var clauses []string
+	var args []any
+
+	add := func(clause string, value any) {
+		args = append(args, value)
+		clauses = append(clauses, fmt.Sprintf(clause, len(args)))
+	}
+
+	if filter.Retailer != "" {
+		add("retailer = $%d", filter.Retailer)
+	}
+	if filter.DateRange != nil {
+		if filter.DateRange.From != "" {
+			add("purchase_date >= $%d", filter.DateRange.From)
+		}
+		if filter.DateRange.To != "" {
+			add("purchase_date <= $%d", filter.DateRange.To)
+		}
+	}
+	if filter.TotalRange != nil {
+		if filter.TotalRange.Min != 0 {
+			add("total >= $%d", filter.TotalRange.Min)
+		}
+		if filter.TotalRange.Max != 0 {
+			add("total <= $%d", filter.TotalRange.Max)
+		}
+	}
+	if filter.MinPoints != nil {
+		add("points >= $%d", *filter.MinPoints)
+	}
+	if filter.MaxPoints != nil {
+		add("points <= $%d", *filter.MaxPoints)
+	}
+
+	if len(clauses) == 0 {
+		return "", nil
+	}
+	return " WHERE " + strings.Join(clauses, " AND "), args
+}
+
+// Close flushes Postgres's pending writes and closes the connection pool.
+func (s *PostgresStore) Close() error {
+	return s.db.Close()
+}
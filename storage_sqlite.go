@@ -0,0 +1,185 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// SQLiteStore persists receipts in a SQLite database file.
+type SQLiteStore struct {
+	db *sql.DB
+}
+
+func NewSQLiteStore(path string) (*SQLiteStore, error) {
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: open %q: %w", path, err)
+	}
+
+	if err := runMigrations(context.Background(), db, sqliteMigrations, "migrations/sqlite", questionPlaceholder); err != nil {
+		return nil, fmt.Errorf("sqlite: %w", err)
+	}
+
+	return &SQLiteStore{db: db}, nil
+}
+
+func (s *SQLiteStore) Save(ctx context.Context, id string, receipt Receipt, score ScoreResult) error {
+	receiptData, err := json.Marshal(receipt)
+	if err != nil {
+		return fmt.Errorf("sqlite: marshal receipt: %w", err)
+	}
+	breakdownData, err := json.Marshal(score.Breakdown)
+	if err != nil {
+		return fmt.Errorf("sqlite: marshal breakdown: %w", err)
+	}
+
+	total, _ := strconv.ParseFloat(receipt.Total, 64)
+
+	_, err = s.db.ExecContext(ctx,
+		`INSERT INTO receipts (id, receipt, points, rule_version, breakdown, retailer, purchase_date, total)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		 ON CONFLICT(id) DO UPDATE SET receipt = excluded.receipt, points = excluded.points,
+		 rule_version = excluded.rule_version, breakdown = excluded.breakdown,
+		 retailer = excluded.retailer, purchase_date = excluded.purchase_date, total = excluded.total`,
+		id, receiptData, score.Points, score.Version, breakdownData, receipt.Retailer, receipt.PurchaseDate, total,
+	)
+	if err != nil {
+		return fmt.Errorf("sqlite: save %q: %w", id, err)
+	}
+	return nil
+}
+
+func (s *SQLiteStore) UpdateStatus(ctx context.Context, id, status, comment string) (bool, error) {
+	result, err := s.db.ExecContext(ctx,
+		`UPDATE receipts SET status = ?, comment = ? WHERE id = ?`,
+		status, comment, id,
+	)
+	if err != nil {
+		return false, fmt.Errorf("sqlite: update status %q: %w", id, err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("sqlite: update status %q: %w", id, err)
+	}
+	return rows > 0, nil
+}
+
+func (s *SQLiteStore) GetPoints(ctx context.Context, id string) (int, bool, error) {
+	var points int
+	err := s.db.QueryRowContext(ctx, `SELECT points FROM receipts WHERE id = ?`, id).Scan(&points)
+	if err == sql.ErrNoRows {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("sqlite: get points %q: %w", id, err)
+	}
+	return points, true, nil
+}
+
+func (s *SQLiteStore) Get(ctx context.Context, id string) (Record, bool, error) {
+	var record Record
+	var receiptData, breakdownData []byte
+
+	row := s.db.QueryRowContext(ctx, `SELECT id, receipt, points, rule_version, breakdown, status, comment FROM receipts WHERE id = ?`, id)
+	err := row.Scan(&record.ID, &receiptData, &record.Score.Points, &record.Score.Version, &breakdownData, &record.Status, &record.Comment)
+	if err == sql.ErrNoRows {
+		return Record{}, false, nil
+	}
+	if err != nil {
+		return Record{}, false, fmt.Errorf("sqlite: get %q: %w", id, err)
+	}
+
+	if err := json.Unmarshal(receiptData, &record.Receipt); err != nil {
+		return Record{}, false, fmt.Errorf("sqlite: unmarshal receipt %q: %w", id, err)
+	}
+	if err := json.Unmarshal(breakdownData, &record.Score.Breakdown); err != nil {
+		return Record{}, false, fmt.Errorf("sqlite: unmarshal breakdown %q: %w", id, err)
+	}
+
+	return record, true, nil
+}
+
+func (s *SQLiteStore) List(ctx context.Context, filter ListFilter) ([]Record, error) {
+	where, args := sqliteFilterClause(filter)
+	query := `SELECT id, receipt, points, rule_version, breakdown, status, comment FROM receipts` + where
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: list: %w", err)
+	}
+	defer rows.Close()
+
+	var records []Record
+	for rows.Next() {
+		var record Record
+		var receiptData, breakdownData []byte
+		if err := rows.Scan(&record.ID, &receiptData, &record.Score.Points, &record.Score.Version, &breakdownData, &record.Status, &record.Comment); err != nil {
+			return nil, fmt.Errorf("sqlite: scan row: %w", err)
+		}
+		if err := json.Unmarshal(receiptData, &record.Receipt); err != nil {
+			return nil, fmt.Errorf("sqlite: unmarshal receipt %q: %w", record.ID, err)
+		}
+		if err := json.Unmarshal(breakdownData, &record.Score.Breakdown); err != nil {
+			return nil, fmt.Errorf("sqlite: unmarshal breakdown %q: %w", record.ID, err)
+		}
+		records = append(records, record)
+	}
+	return records, rows.Err()
+}
+
+// sqliteFilterClause translates a ListFilter into a "WHERE ..." clause (or
+// "" if the filter is empty) plus its positional arguments.
+func sqliteFilterClause(filter ListFilter) (string, []any) {
+	var clauses []string
+	var args []any
+
+	if filter.Retailer != "" {
+		clauses = append(clauses, "retailer = ?")
+		args = append(args, filter.Retailer)
+	}
+	if filter.DateRange != nil {
+		if filter.DateRange.From != "" {
+			clauses = append(clauses, "purchase_date >= ?")
+			args = append(args, filter.DateRange.From)
+		}
+		if filter.DateRange.To != "" {
+			clauses = append(clauses, "purchase_date <= ?")
+			args = append(args, filter.DateRange.To)
+		}
+	}
+	if filter.TotalRange != nil {
+		if filter.TotalRange.Min != 0 {
+			clauses = append(clauses, "total >= ?")
+			args = append(args, filter.TotalRange.Min)
+		}
+		if filter.TotalRange.Max != 0 {
+			clauses = append(clauses, "total <= ?")
+			args = append(args, filter.TotalRange.Max)
+		}
+	}
+	if filter.MinPoints != nil {
+		clauses = append(clauses, "points >= ?")
+		args = append(args, *filter.MinPoints)
+	}
+	if filter.MaxPoints != nil {
+		clauses = append(clauses, "points <= ?")
+		args = append(args, *filter.MaxPoints)
+	}
+
+	if len(clauses) == 0 {
+		return "", nil
+	}
+	return " WHERE " + strings.Join(clauses, " AND "), args
+}
+
+// Close flushes SQLite's pending writes and closes the database handle.
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
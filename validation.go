@@ -0,0 +1,115 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaxBodyBytes caps the size of request bodies accepted by the receipt
+// endpoints, guarding against unbounded memory use from oversized payloads.
+// It defaults to 1 MiB but is overridden by main via the --max-body-bytes
+// flag (or RECEIPT_MAX_BODY_BYTES env var), mirroring the other runtime
+// knobs in this series.
+var MaxBodyBytes int64 = 1 << 20
+
+var (
+	retailerPattern = regexp.MustCompile(`^[\w\s\-&]+$`)
+	amountPattern   = regexp.MustCompile(`^\d+\.\d{2}$`)
+)
+
+// ValidationError is a single field violation, returned to the client as
+// structured JSON rather than a plain-text message.
+type ValidationError struct {
+	Code    string `json:"code"`
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// validateReceipt checks a decoded Receipt against the official schema,
+// returning every violation found rather than stopping at the first.
+func validateReceipt(receipt Receipt) []ValidationError {
+	var errs []ValidationError
+
+	if !retailerPattern.MatchString(receipt.Retailer) {
+		errs = append(errs, ValidationError{
+			Code:    "invalid_format",
+			Field:   "retailer",
+			Message: "retailer must match ^[\\w\\s\\-&]+$",
+		})
+	}
+
+	if !amountPattern.MatchString(receipt.Total) {
+		errs = append(errs, ValidationError{
+			Code:    "invalid_format",
+			Field:   "total",
+			Message: "total must match ^\\d+\\.\\d{2}$",
+		})
+	}
+
+	if len(receipt.Items) == 0 {
+		errs = append(errs, ValidationError{
+			Code:    "required",
+			Field:   "items",
+			Message: "items must not be empty",
+		})
+	}
+
+	for i, item := range receipt.Items {
+		if !amountPattern.MatchString(item.Price) {
+			errs = append(errs, ValidationError{
+				Code:    "invalid_format",
+				Field:   itemField(i, "price"),
+				Message: "price must match ^\\d+\\.\\d{2}$",
+			})
+		}
+	}
+
+	// The schema calls for an RFC3339 date, which for a date-only field
+	// means its YYYY-MM-DD prefix (the layout the rule engine already parses).
+	if _, err := time.Parse("2006-01-02", receipt.PurchaseDate); err != nil {
+		errs = append(errs, ValidationError{
+			Code:    "invalid_format",
+			Field:   "purchaseDate",
+			Message: "purchaseDate must be RFC3339 (YYYY-MM-DD)",
+		})
+	}
+
+	if _, err := time.Parse("15:04", receipt.PurchaseTime); err != nil {
+		errs = append(errs, ValidationError{
+			Code:    "invalid_format",
+			Field:   "purchaseTime",
+			Message: "purchaseTime must match HH:MM",
+		})
+	}
+
+	return errs
+}
+
+// joinValidationErrors flattens validation errors into a single message, for
+// callers (like the bulk endpoints) that report one error string per item
+// rather than structured per-field errors.
+func joinValidationErrors(errs []ValidationError) string {
+	messages := make([]string, len(errs))
+	for i, err := range errs {
+		messages[i] = err.Field + ": " + err.Message
+	}
+	return strings.Join(messages, "; ")
+}
+
+// writeValidationErrors responds with HTTP 400 and one structured JSON
+// error per schema violation.
+func writeValidationErrors(writer http.ResponseWriter, errs []ValidationError) {
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusBadRequest)
+	json.NewEncoder(writer).Encode(struct {
+		Errors []ValidationError `json:"errors"`
+	}{Errors: errs})
+}
+
+func itemField(index int, field string) string {
+	return "items[" + strconv.Itoa(index) + "]." + field
+}
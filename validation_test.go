@@ -0,0 +1,168 @@
+package main
+
+import "testing"
+
+func validReceiptFixture() Receipt {
+	return Receipt{
+		Retailer:     "Target Store 22",
+		PurchaseDate: "2022-01-01",
+		PurchaseTime: "13:01",
+		Total:        "35.00",
+		Items: []Item{
+			{ShortDescr: "Mountain Dew 12PK", Price: "6.49"},
+		},
+	}
+}
+
+func hasCode(errs []ValidationError, field, code string) bool {
+	for _, err := range errs {
+		if err.Field == field && err.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+func TestValidateReceiptValid(t *testing.T) {
+	if errs := validateReceipt(validReceiptFixture()); len(errs) != 0 {
+		t.Errorf("validateReceipt() = %v, want no errors", errs)
+	}
+}
+
+func TestValidateReceiptRetailer(t *testing.T) {
+	tests := []struct {
+		name     string
+		retailer string
+		wantErr  bool
+	}{
+		{"alphanumeric", "Target", false},
+		{"with space, dash, ampersand", "M&M Corner Market - 5", false},
+		{"empty", "", true},
+		{"disallowed character", "Target!", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			receipt := validReceiptFixture()
+			receipt.Retailer = tt.retailer
+
+			errs := validateReceipt(receipt)
+			got := hasCode(errs, "retailer", "invalid_format")
+			if got != tt.wantErr {
+				t.Errorf("retailer %q: invalid_format error = %v, want %v", tt.retailer, got, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateReceiptTotal(t *testing.T) {
+	tests := []struct {
+		name    string
+		total   string
+		wantErr bool
+	}{
+		{"two decimals", "35.00", false},
+		{"missing decimals", "35", true},
+		{"one decimal digit", "35.0", true},
+		{"non-numeric", "abc", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			receipt := validReceiptFixture()
+			receipt.Total = tt.total
+
+			errs := validateReceipt(receipt)
+			got := hasCode(errs, "total", "invalid_format")
+			if got != tt.wantErr {
+				t.Errorf("total %q: invalid_format error = %v, want %v", tt.total, got, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateReceiptItems(t *testing.T) {
+	receipt := validReceiptFixture()
+	receipt.Items = nil
+
+	errs := validateReceipt(receipt)
+	if !hasCode(errs, "items", "required") {
+		t.Errorf("validateReceipt() = %v, want a required error on items", errs)
+	}
+}
+
+func TestValidateReceiptItemPrice(t *testing.T) {
+	receipt := validReceiptFixture()
+	receipt.Items = []Item{
+		{ShortDescr: "Valid Item", Price: "1.00"},
+		{ShortDescr: "Bad Item", Price: "free"},
+	}
+
+	errs := validateReceipt(receipt)
+	if !hasCode(errs, "items[1].price", "invalid_format") {
+		t.Errorf("validateReceipt() = %v, want an invalid_format error on items[1].price", errs)
+	}
+}
+
+func TestValidateReceiptPurchaseDate(t *testing.T) {
+	tests := []struct {
+		name    string
+		date    string
+		wantErr bool
+	}{
+		{"valid date", "2022-01-01", false},
+		{"wrong format", "01/01/2022", true},
+		{"empty", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			receipt := validReceiptFixture()
+			receipt.PurchaseDate = tt.date
+
+			errs := validateReceipt(receipt)
+			got := hasCode(errs, "purchaseDate", "invalid_format")
+			if got != tt.wantErr {
+				t.Errorf("purchaseDate %q: invalid_format error = %v, want %v", tt.date, got, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateReceiptPurchaseTime(t *testing.T) {
+	tests := []struct {
+		name    string
+		time    string
+		wantErr bool
+	}{
+		{"valid time", "13:01", false},
+		{"missing leading zero is still valid", "1:01", false},
+		{"wrong format", "1:01 PM", true},
+		{"empty", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			receipt := validReceiptFixture()
+			receipt.PurchaseTime = tt.time
+
+			errs := validateReceipt(receipt)
+			got := hasCode(errs, "purchaseTime", "invalid_format")
+			if got != tt.wantErr {
+				t.Errorf("purchaseTime %q: invalid_format error = %v, want %v", tt.time, got, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestJoinValidationErrors(t *testing.T) {
+	errs := []ValidationError{
+		{Code: "invalid_format", Field: "total", Message: "total must match ^\\d+\\.\\d{2}$"},
+		{Code: "required", Field: "items", Message: "items must not be empty"},
+	}
+
+	want := "total: total must match ^\\d+\\.\\d{2}$; items: items must not be empty"
+	if got := joinValidationErrors(errs); got != want {
+		t.Errorf("joinValidationErrors() = %q, want %q", got, want)
+	}
+}